@@ -0,0 +1,161 @@
+package simtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vlence/gossert"
+)
+
+// simFuncTimer is a one-shot timer like SimTimer, except its deadline
+// runs a callback instead of sending on a channel. It backs
+// SimClock.AfterFunc and SimClock.AfterFuncLocked.
+type simFuncTimer struct {
+	mu       *sync.Mutex
+	stopped  bool
+	deadline SimMonotonicTime
+	seq      uint64
+	f        func()
+	locker   sync.Locker
+	events   *timerEvents
+}
+
+// newSimFuncTimer returns a new simFuncTimer that runs f once deadline
+// is reached, holding locker (if not nil) for the duration of f. seq is
+// its insertion sequence number, used to order it against other timers
+// sharing its deadline.
+func newSimFuncTimer(deadline SimMonotonicTime, seq uint64, f func(), locker sync.Locker, events *timerEvents) *simFuncTimer {
+	gossert.Ok(f != nil, "simfunctimer: callback is nil")
+	gossert.Ok(events != nil, "simfunctimer: timer events is nil")
+
+	timer := new(simFuncTimer)
+	timer.mu = new(sync.Mutex)
+	timer.stopped = false
+	timer.deadline = deadline
+	timer.seq = seq
+	timer.f = f
+	timer.locker = locker
+	timer.events = events
+
+	return timer
+}
+
+// Reset reschedules the timer to run its callback after d has passed
+// since Reset is called. If the callback has already run, or the timer
+// was previously stopped, Reset does nothing.
+func (timer *simFuncTimer) Reset(d time.Duration) bool {
+	timer.mu.Lock()
+	defer timer.mu.Unlock()
+
+	if timer.stopped {
+		return false
+	}
+
+	timer.deadline = timer.events.clock.NowMonotonic().Add(d)
+
+	return true
+}
+
+// Stop prevents the timer's callback from running, if it hasn't run
+// already.
+func (timer *simFuncTimer) Stop() bool {
+	timer.mu.Lock()
+	defer timer.mu.Unlock()
+
+	if timer.stopped {
+		return false
+	}
+
+	timer.events.remove(timer)
+	timer.stopped = true
+
+	return true
+}
+
+// Missed always returns 0: fire calls the callback directly instead of
+// sending on a channel, so there's nothing for it to drop.
+func (timer *simFuncTimer) Missed() int {
+	return 0
+}
+
+// nextDeadline returns the time at which the timer's callback will run.
+func (timer *simFuncTimer) nextDeadline() SimMonotonicTime {
+	timer.mu.Lock()
+	defer timer.mu.Unlock()
+
+	return timer.deadline
+}
+
+// insertionSeq returns the timer's insertion sequence number.
+func (timer *simFuncTimer) insertionSeq() uint64 {
+	return timer.seq
+}
+
+// fire runs the timer's callback if its deadline has been reached. The
+// locker, if any, is acquired before the callback runs, and whether the
+// timer was stopped is re-checked once it's held: this closes the race
+// where Stop is called after the deadline has passed but before the
+// callback has actually started running.
+func (timer *simFuncTimer) fire(now SimMonotonicTime) bool {
+	timer.mu.Lock()
+	if timer.stopped {
+		timer.mu.Unlock()
+		return false
+	}
+
+	passedDeadline := now.After(timer.deadline) || now.Equal(timer.deadline)
+	if !passedDeadline {
+		timer.mu.Unlock()
+		return false
+	}
+	timer.mu.Unlock()
+
+	if timer.locker != nil {
+		timer.locker.Lock()
+		defer timer.locker.Unlock()
+	}
+
+	timer.mu.Lock()
+	cancelled := timer.stopped
+	timer.stopped = true
+	timer.mu.Unlock()
+
+	if !cancelled {
+		timer.f()
+	}
+
+	return true
+}
+
+// AfterFunc waits for d to pass and then calls f. It returns a Timer
+// that can be used to cancel the call using its Stop method. f runs
+// synchronously as part of the Tick call that crosses the deadline, so
+// within one Tick call, callbacks run in order of deadline, breaking
+// ties by creation order.
+func (clock *SimClock) AfterFunc(d time.Duration, f func()) Timer {
+	return clock.afterFunc(d, nil, f)
+}
+
+// AfterFuncLocked is like AfterFunc, except l is held for the duration
+// of f. This mirrors the cancellable-timer pattern used for network
+// stack timers: acquiring l before running f and re-checking
+// cancellation once l is held closes the race where Stop is called
+// between deadline expiry and callback entry.
+func (clock *SimClock) AfterFuncLocked(d time.Duration, l sync.Locker, f func()) Timer {
+	gossert.Ok(l != nil, "simclock: locker is nil")
+
+	return clock.afterFunc(d, l, f)
+}
+
+func (clock *SimClock) afterFunc(d time.Duration, l sync.Locker, f func()) Timer {
+	gossert.Ok(f != nil, "simclock: callback is nil")
+
+	clock.mu.Lock()
+	deadline := clock.now.Add(d)
+	clock.mu.Unlock()
+
+	timer := newSimFuncTimer(deadline, clock.nextInsertionSeq(), f, l, clock.events)
+	clock.events.add(timer)
+
+	return timer
+}
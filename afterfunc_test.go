@@ -0,0 +1,107 @@
+package simtest
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAfterFuncRunsAtDeadline(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	dur := 1 * time.Second
+	ran := make(chan struct{}, 1)
+	clock.AfterFunc(dur, func() {
+		ran <- struct{}{}
+	})
+
+	tickSize := 100 * time.Millisecond
+	iters := dur/tickSize + 2
+	for range iters {
+		select {
+		case <-ran:
+			return
+		default:
+			clock.Tick(tickSize)
+		}
+	}
+
+	t.Errorf("callback was not run")
+}
+
+func TestAfterFuncStopPreventsCallback(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	dur := 1 * time.Second
+	ran := false
+	timer := clock.AfterFunc(dur, func() {
+		ran = true
+	})
+
+	timer.Stop()
+	clock.Tick(10 * dur)
+
+	if ran {
+		t.Errorf("callback ran after timer was stopped")
+	}
+}
+
+func TestAfterFuncsRunInDeadlineOrder(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	var order []int
+	var mu sync.Mutex
+
+	record := func(i int) func() {
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, i)
+		}
+	}
+
+	clock.AfterFunc(3*time.Second, record(3))
+	clock.AfterFunc(1*time.Second, record(1))
+	clock.AfterFunc(2*time.Second, record(2))
+
+	clock.Tick(5 * time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	want := []int{1, 2, 3}
+	if len(order) != len(want) {
+		t.Fatalf("got %v callbacks, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("callbacks ran in order %v, want %v", order, want)
+			break
+		}
+	}
+}
+
+func TestAfterFuncLockedHoldsLockDuringCallback(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	var l sync.Mutex
+	locked := false
+
+	clock.AfterFuncLocked(1*time.Second, &l, func() {
+		locked = !l.TryLock()
+	})
+
+	clock.Tick(1 * time.Second)
+
+	if !locked {
+		t.Errorf("callback ran without locker held")
+	}
+}
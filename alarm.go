@@ -0,0 +1,91 @@
+package simtest
+
+import "time"
+
+// Alarm represents an absolute-time alarm: an action that needs to run
+// at a specific moment in (simulated) time, rather than after a
+// relative duration like Timer. The interface is deliberately kept
+// similar to that of Timer.
+type Alarm interface {
+	Reset(t time.Time) bool
+
+	Stop() bool
+}
+
+// SimAlarm is a SimTimer whose Reset takes an absolute deadline instead
+// of a duration to add to the current one.
+type SimAlarm struct {
+	*SimTimer
+}
+
+// newSimAlarm returns a new SimAlarm that fires at deadline. seq is its
+// insertion sequence number, used to order it against other timers
+// sharing its deadline.
+func newSimAlarm(deadline SimMonotonicTime, seq uint64, events *timerEvents) *SimAlarm {
+	return &SimAlarm{newSimTimer(deadline, seq, TimerOptions{}, events)}
+}
+
+// Reset updates the alarm to fire at t instead of its current deadline.
+// If the alarm has already fired or was previously stopped then Reset
+// does nothing.
+func (alarm *SimAlarm) Reset(t time.Time) bool {
+	alarm.mu.Lock()
+	defer alarm.mu.Unlock()
+
+	if alarm.stopped {
+		return false
+	}
+
+	alarm.deadline = alarm.events.monotonic(t)
+
+	return true
+}
+
+// simFuncAlarm is a simFuncTimer whose Reset takes an absolute deadline
+// instead of a duration, backing SimClock.AtFunc.
+type simFuncAlarm struct {
+	*simFuncTimer
+}
+
+// Reset updates the alarm to run its callback at t instead of its
+// current deadline. If the callback has already run, or the alarm was
+// previously stopped, Reset does nothing.
+func (alarm *simFuncAlarm) Reset(t time.Time) bool {
+	alarm.mu.Lock()
+	defer alarm.mu.Unlock()
+
+	if alarm.stopped {
+		return false
+	}
+
+	alarm.deadline = alarm.events.monotonic(t)
+
+	return true
+}
+
+// At returns a channel that receives the current simulated time once t
+// is reached. It's a convenience wrapper around NewAlarm for callers
+// that don't need to Stop or Reset the alarm, mirroring time.After.
+func (clock *SimClock) At(t time.Time) <-chan time.Time {
+	_, ch := clock.NewAlarm(t)
+	return ch
+}
+
+// NewAlarm returns an Alarm that fires at t, and the channel it'll send
+// the current simulated time on when it does.
+func (clock *SimClock) NewAlarm(t time.Time) (Alarm, <-chan time.Time) {
+	alarm := newSimAlarm(clock.events.monotonic(t), clock.nextInsertionSeq(), clock.events)
+	clock.events.add(alarm)
+
+	return alarm, alarm.ch
+}
+
+// AtFunc waits for t to be reached and then calls f. It returns an
+// Alarm that can be used to cancel the call using its Stop method, or
+// reschedule it to a new absolute time using its Reset method.
+func (clock *SimClock) AtFunc(t time.Time, f func()) Alarm {
+	timer := newSimFuncTimer(clock.events.monotonic(t), clock.nextInsertionSeq(), f, nil, clock.events)
+	clock.events.add(timer)
+
+	return &simFuncAlarm{timer}
+}
@@ -0,0 +1,95 @@
+package simtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlarmFiresAtAbsoluteTime(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	deadline := epoch.Add(1 * time.Second)
+	ch := clock.At(deadline)
+
+	tickSize := 100 * time.Millisecond
+	for range 12 {
+		select {
+		case now := <-ch:
+			if !now.Equal(deadline) {
+				t.Errorf("alarm fired at %s but should have been fired at %s", now, deadline)
+			}
+			return
+		default:
+			clock.Tick(tickSize)
+		}
+	}
+
+	t.Errorf("alarm wasn't fired")
+}
+
+func TestAlarmResetUsesAbsoluteTime(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	alarm, ch := clock.NewAlarm(epoch.Add(1 * time.Second))
+
+	newDeadline := epoch.Add(5 * time.Second)
+	alarm.Reset(newDeadline)
+
+	clock.Tick(1 * time.Second)
+
+	select {
+	case <-ch:
+		t.Errorf("alarm fired before its new deadline")
+	default:
+	}
+
+	clock.Tick(4 * time.Second)
+
+	select {
+	case now := <-ch:
+		if !now.Equal(newDeadline) {
+			t.Errorf("alarm fired at %s but should have been fired at %s", now, newDeadline)
+		}
+	default:
+		t.Errorf("alarm wasn't fired after its new deadline")
+	}
+}
+
+func TestAtFuncRunsAtAbsoluteTime(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	ran := false
+	clock.AtFunc(epoch.Add(1*time.Second), func() {
+		ran = true
+	})
+
+	clock.Tick(2 * time.Second)
+
+	if !ran {
+		t.Errorf("callback was not run")
+	}
+}
+
+func TestAtFuncStopPreventsCallback(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	ran := false
+	alarm := clock.AtFunc(epoch.Add(1*time.Second), func() {
+		ran = true
+	})
+
+	alarm.Stop()
+	clock.Tick(2 * time.Second)
+
+	if ran {
+		t.Errorf("callback ran after alarm was stopped")
+	}
+}
@@ -0,0 +1,318 @@
+package simtest
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// fireable is implemented by anything a SimClock can advance past its
+// deadline: SimTimer, SimTicker and the timer returned by AfterFunc.
+// fire reports whether the clock should stop tracking it, which is
+// true for a one-shot timer once it has fired and false for a
+// SimTicker, which keeps ticking until Stop is called. insertionSeq
+// breaks ties between timers that share a deadline: the clock always
+// fires them in the order they were created.
+type fireable interface {
+	fire(now SimMonotonicTime) bool
+	nextDeadline() SimMonotonicTime
+	insertionSeq() uint64
+}
+
+// timerEvents is how SimTimer, SimTicker and friends talk back to the
+// SimClock that created them: registering themselves when they're
+// created and asking to be removed when they're stopped. epoch is the
+// wall-clock instant SimMonotonicTime(0) corresponds to, so timers can
+// turn their monotonic deadlines back into a time.Time to send on their
+// channel.
+type timerEvents struct {
+	epoch time.Time
+	clock *SimClock
+}
+
+// monotonic turns an absolute time.Time into the SimMonotonicTime it
+// corresponds to on the clock these events belong to.
+func (events *timerEvents) monotonic(t time.Time) SimMonotonicTime {
+	return SimMonotonicTime(t.Sub(events.epoch))
+}
+
+// add registers f with the clock so it's advanced by future Ticks.
+func (events *timerEvents) add(f fireable) {
+	events.clock.mu.Lock()
+	defer events.clock.mu.Unlock()
+
+	events.clock.timers[f] = struct{}{}
+}
+
+// remove stops the clock from advancing f any further.
+func (events *timerEvents) remove(f fireable) {
+	events.clock.mu.Lock()
+	defer events.clock.mu.Unlock()
+
+	delete(events.clock.timers, f)
+}
+
+// Clock is implemented by anything that can create timers, tickers and
+// alarms and advance time for them. SimClock is the only implementation;
+// code that depends on Clock instead of the time package can be driven
+// deterministically in tests.
+type Clock interface {
+	NewTimer(d time.Duration, opts ...TimerOptions) (Timer, <-chan time.Time)
+	NewTicker(d time.Duration, opts ...TimerOptions) (Ticker, <-chan time.Time)
+	AfterFunc(d time.Duration, f func()) Timer
+	AfterFuncLocked(d time.Duration, l sync.Locker, f func()) Timer
+	At(t time.Time) <-chan time.Time
+	NewAlarm(t time.Time) (Alarm, <-chan time.Time)
+	AtFunc(t time.Time, f func()) Alarm
+	Now() time.Time
+	Tick(d time.Duration)
+	TickUntil(t time.Time)
+	TickUntilIdle()
+	Stop()
+}
+
+// SimClock is a simulated clock. Time never passes on its own; tests
+// drive it forward explicitly by calling Tick. Internally, the clock's
+// position is stored as an int64 count of nanoseconds since its epoch
+// (a SimMonotonicTime), so advancing it is a pure integer add and
+// comparing it against a timer's deadline is a pure integer comparison.
+type SimClock struct {
+	mu     sync.Mutex
+	now    SimMonotonicTime
+	nextID uint64
+	timers map[fireable]struct{}
+	events *timerEvents
+}
+
+// NewSimClock returns a new SimClock whose current time is epoch.
+func NewSimClock(epoch time.Time) *SimClock {
+	clock := new(SimClock)
+	clock.now = 0
+	clock.timers = make(map[fireable]struct{})
+	clock.events = &timerEvents{epoch: epoch, clock: clock}
+
+	return clock
+}
+
+// nextInsertionSeq returns a fresh, strictly increasing sequence number,
+// used to order timers that share a deadline by creation order.
+func (clock *SimClock) nextInsertionSeq() uint64 {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	clock.nextID++
+
+	return clock.nextID
+}
+
+// NewTimer returns a Timer that fires once d has passed since NewTimer
+// was called, and the channel it'll send the current simulated time on
+// when it does. opts configures how the timer delivers that event; the
+// zero value matches *time.Timer (a single blocking send).
+func (clock *SimClock) NewTimer(d time.Duration, opts ...TimerOptions) (Timer, <-chan time.Time) {
+	clock.mu.Lock()
+	deadline := clock.now.Add(d)
+	clock.mu.Unlock()
+
+	timer := newSimTimer(deadline, clock.nextInsertionSeq(), firstTimerOptions(opts), clock.events)
+	clock.events.add(timer)
+
+	return timer, timer.ch
+}
+
+// NewTicker returns a Ticker that fires every d once d has passed since
+// NewTicker was called, and the channel it sends the current simulated
+// time on each time it does. opts is accepted for symmetry with
+// NewTimer, but a SimTicker already never blocks the clock and already
+// re-arms itself every period, so its fields have no further effect
+// here.
+func (clock *SimClock) NewTicker(d time.Duration, opts ...TimerOptions) (Ticker, <-chan time.Time) {
+	clock.mu.Lock()
+	deadline := clock.now.Add(d)
+	clock.mu.Unlock()
+
+	ticker := newSimTicker(deadline, d, clock.nextInsertionSeq(), clock.events)
+	clock.events.add(ticker)
+
+	return ticker, ticker.ch
+}
+
+// Now returns the current simulated time.
+func (clock *SimClock) Now() time.Time {
+	return clock.events.epoch.Add(time.Duration(clock.NowMonotonic()))
+}
+
+// NowMonotonic returns the current simulated time as a SimMonotonicTime.
+func (clock *SimClock) NowMonotonic() SimMonotonicTime {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	return clock.now
+}
+
+// Tick advances the simulated clock by d and fires any timer or ticker
+// whose deadline has been passed as a result, earliest deadline first,
+// breaking ties between equal deadlines by creation order.
+func (clock *SimClock) Tick(d time.Duration) {
+	clock.mu.Lock()
+	clock.now = clock.now.Add(d)
+	now := clock.now
+	clock.mu.Unlock()
+
+	clock.fireDue(now)
+}
+
+// TickUntil advances the clock to t, taking the minimum number of
+// sub-steps needed to fire every pending timer and ticker at its own
+// exact deadline, instead of stepping by a fixed size and firing
+// several of them at the same now. Timers created by a callback that
+// itself runs during this call are drained if their own deadline also
+// falls at or before t.
+func (clock *SimClock) TickUntil(t time.Time) {
+	clock.advanceTo(clock.events.monotonic(t))
+}
+
+// TickUntilIdle advances the clock to the furthest-out deadline among
+// currently pending timers and tickers, firing each one at its own
+// exact deadline along the way. If nothing is pending, TickUntilIdle
+// does nothing.
+func (clock *SimClock) TickUntilIdle() {
+	target, ok := clock.furthestPendingDeadline()
+	if !ok {
+		return
+	}
+
+	clock.advanceTo(target)
+}
+
+// snapshotTimers returns the timers and tickers currently registered
+// with the clock. It's used instead of ranging over clock.timers
+// directly so callers can read each fireable's own deadline without
+// holding clock.mu: fireable.nextDeadline takes the fireable's own lock,
+// and a Stop call elsewhere takes that same lock before asking the
+// clock to remove it, so holding both locks at once in opposite orders
+// would deadlock.
+func (clock *SimClock) snapshotTimers() []fireable {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	snapshot := make([]fireable, 0, len(clock.timers))
+	for f := range clock.timers {
+		snapshot = append(snapshot, f)
+	}
+
+	return snapshot
+}
+
+// furthestPendingDeadline returns the latest deadline among currently
+// registered timers and tickers.
+func (clock *SimClock) furthestPendingDeadline() (SimMonotonicTime, bool) {
+	var target SimMonotonicTime
+	found := false
+
+	for _, f := range clock.snapshotTimers() {
+		d := f.nextDeadline()
+		if !found || d.After(target) {
+			target = d
+			found = true
+		}
+	}
+
+	return target, found
+}
+
+// earliestPendingDeadline returns the earliest deadline among currently
+// registered timers and tickers that falls at or before target.
+func (clock *SimClock) earliestPendingDeadline(target SimMonotonicTime) (SimMonotonicTime, bool) {
+	var next SimMonotonicTime
+	found := false
+
+	for _, f := range clock.snapshotTimers() {
+		d := f.nextDeadline()
+		if d.After(target) {
+			continue
+		}
+		if !found || d.Before(next) {
+			next = d
+			found = true
+		}
+	}
+
+	return next, found
+}
+
+// advanceTo moves the clock to target in the fewest sub-steps needed to
+// fire every pending timer and ticker at its own exact deadline along
+// the way, draining timers newly created by a callback as long as their
+// own deadline also falls at or before target.
+func (clock *SimClock) advanceTo(target SimMonotonicTime) {
+	for {
+		clock.mu.Lock()
+		now := clock.now
+		clock.mu.Unlock()
+
+		next, ok := clock.earliestPendingDeadline(target)
+		if !ok {
+			break
+		}
+
+		if next.Before(now) {
+			next = now
+		}
+
+		clock.mu.Lock()
+		clock.now = next
+		clock.mu.Unlock()
+
+		clock.fireDue(next)
+	}
+
+	clock.mu.Lock()
+	if clock.now.Before(target) {
+		clock.now = target
+	}
+	now := clock.now
+	clock.mu.Unlock()
+
+	clock.fireDue(now)
+}
+
+// fireDue fires every registered timer and ticker whose deadline is at
+// or before now, in (deadline, insertionSeq) order. Firing a timer can
+// register new ones (e.g. an AfterFunc callback scheduling another), so
+// fireDue keeps re-checking for newly pending work until none remains.
+func (clock *SimClock) fireDue(now SimMonotonicTime) {
+	for {
+		var pending []fireable
+		for _, f := range clock.snapshotTimers() {
+			if !f.nextDeadline().After(now) {
+				pending = append(pending, f)
+			}
+		}
+
+		if len(pending) == 0 {
+			return
+		}
+
+		sort.Slice(pending, func(i, j int) bool {
+			a, b := pending[i].nextDeadline(), pending[j].nextDeadline()
+			if !a.Equal(b) {
+				return a.Before(b)
+			}
+			return pending[i].insertionSeq() < pending[j].insertionSeq()
+		})
+
+		for _, f := range pending {
+			if f.fire(now) {
+				clock.events.remove(f)
+			}
+		}
+	}
+}
+
+// Stop marks the clock as no longer in use. SimClock keeps no
+// background goroutines, so Stop has nothing to shut down; it exists so
+// callers that depend on Clock can treat SimClock like any other Clock
+// implementation that does.
+func (clock *SimClock) Stop() {
+}
@@ -0,0 +1,116 @@
+package simtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTickUntilFiresEachTimerAtItsOwnDeadline(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	_, ch1 := clock.NewTimer(1 * time.Second)
+	_, ch2 := clock.NewTimer(3 * time.Second)
+
+	clock.TickUntil(epoch.Add(5 * time.Second))
+
+	select {
+	case now := <-ch1:
+		want := epoch.Add(1 * time.Second)
+		if !now.Equal(want) {
+			t.Errorf("first timer fired at %s, want %s", now, want)
+		}
+	default:
+		t.Errorf("first timer did not fire")
+	}
+
+	select {
+	case now := <-ch2:
+		want := epoch.Add(3 * time.Second)
+		if !now.Equal(want) {
+			t.Errorf("second timer fired at %s, want %s", now, want)
+		}
+	default:
+		t.Errorf("second timer did not fire")
+	}
+
+	if now := clock.Now(); !now.Equal(epoch.Add(5 * time.Second)) {
+		t.Errorf("clock is at %s, want %s", now, epoch.Add(5*time.Second))
+	}
+}
+
+func TestTickUntilDrainsTimersCreatedDuringItself(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	var chained <-chan time.Time
+	clock.AfterFunc(1*time.Second, func() {
+		_, ch := clock.NewTimer(1 * time.Second)
+		chained = ch
+	})
+
+	clock.TickUntil(epoch.Add(5 * time.Second))
+
+	select {
+	case <-chained:
+	default:
+		t.Errorf("timer created during TickUntil did not fire by the end of the same call")
+	}
+}
+
+func TestTickUntilIdleStopsAtFurthestPendingDeadline(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	clock.NewTimer(1 * time.Second)
+	clock.NewTimer(4 * time.Second)
+
+	clock.TickUntilIdle()
+
+	want := epoch.Add(4 * time.Second)
+	if now := clock.Now(); !now.Equal(want) {
+		t.Errorf("clock is at %s, want %s", now, want)
+	}
+}
+
+func TestTickUntilIdleDoesNothingWithoutPendingTimers(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	clock.TickUntilIdle()
+
+	if now := clock.Now(); !now.Equal(epoch) {
+		t.Errorf("clock moved to %s with nothing pending, want it to stay at %s", now, epoch)
+	}
+}
+
+func TestTimersSharingADeadlineFireInCreationOrder(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	var order []int
+	for i := range 3 {
+		i := i
+		clock.AfterFunc(1*time.Second, func() {
+			order = append(order, i)
+		})
+	}
+
+	clock.Tick(1 * time.Second)
+
+	want := []int{0, 1, 2}
+	if len(order) != len(want) {
+		t.Fatalf("got %v callbacks, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("callbacks ran in order %v, want %v", order, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package simtest
+
+import "time"
+
+// SimMonotonicTime is a point in simulated time, stored as a count of
+// nanoseconds since the clock's epoch. Unlike time.Time, which carries
+// an optional monotonic reading alongside a wall clock reading and has
+// to reconcile the two in After/Equal, a SimMonotonicTime is just an
+// int64, so comparing two of them is a plain integer comparison.
+type SimMonotonicTime int64
+
+// Add returns the SimMonotonicTime t+d.
+func (t SimMonotonicTime) Add(d time.Duration) SimMonotonicTime {
+	return t + SimMonotonicTime(d)
+}
+
+// Sub returns the duration t-u.
+func (t SimMonotonicTime) Sub(u SimMonotonicTime) time.Duration {
+	return time.Duration(t - u)
+}
+
+// Before reports whether t is before u.
+func (t SimMonotonicTime) Before(u SimMonotonicTime) bool {
+	return t < u
+}
+
+// After reports whether t is after u.
+func (t SimMonotonicTime) After(u SimMonotonicTime) bool {
+	return t > u
+}
+
+// Equal reports whether t and u are the same instant.
+func (t SimMonotonicTime) Equal(u SimMonotonicTime) bool {
+	return t == u
+}
@@ -0,0 +1,60 @@
+package simtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimClockNowAdvancesWithTick(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	d := 500 * time.Millisecond
+	clock.Tick(d)
+
+	want := epoch.Add(d)
+	if !clock.Now().Equal(want) {
+		t.Errorf("clock.Now() is %s, want %s", clock.Now(), want)
+	}
+}
+
+func TestSimClockNowMonotonicStartsAtZero(t *testing.T) {
+	clock := NewSimClock(time.Now())
+	defer clock.Stop()
+
+	if clock.NowMonotonic() != 0 {
+		t.Errorf("clock.NowMonotonic() is %d, want 0", clock.NowMonotonic())
+	}
+
+	clock.Tick(3 * time.Second)
+
+	if clock.NowMonotonic() != SimMonotonicTime(3*time.Second) {
+		t.Errorf("clock.NowMonotonic() is %d, want %d", clock.NowMonotonic(), SimMonotonicTime(3*time.Second))
+	}
+}
+
+func TestSimMonotonicTimeComparisons(t *testing.T) {
+	a := SimMonotonicTime(1 * time.Second)
+	b := SimMonotonicTime(2 * time.Second)
+
+	if !a.Before(b) {
+		t.Errorf("a should be before b")
+	}
+
+	if !b.After(a) {
+		t.Errorf("b should be after a")
+	}
+
+	if !a.Equal(SimMonotonicTime(1 * time.Second)) {
+		t.Errorf("a should equal itself")
+	}
+
+	if a.Add(1 * time.Second) != b {
+		t.Errorf("a.Add(1s) should equal b")
+	}
+
+	if b.Sub(a) != 1*time.Second {
+		t.Errorf("b.Sub(a) should be 1s, got %s", b.Sub(a))
+	}
+}
@@ -0,0 +1,40 @@
+package simtest
+
+import "time"
+
+// TimerOptions configures how a SimTimer delivers its events, mirroring
+// the fake-clock waiter options used by Kubernetes'
+// apimachinery/util/clock. It lets one timer cover both a plain
+// one-shot/ticker and the "non-blocking, possibly-repeating" timers
+// that network stacks and schedulers tend to build on top of *time.Timer
+// themselves, without every caller having to hand-roll that state
+// machine. NewTicker accepts TimerOptions too, but a SimTicker ignores
+// its fields: it always sends non-blocking and re-arms every period.
+type TimerOptions struct {
+	// SkipIfBlocked makes fire a non-blocking send: if nothing is
+	// ready to receive, the event is dropped instead of fire blocking
+	// the call that is advancing the clock. Dropped events are counted
+	// and can be inspected with Missed.
+	SkipIfBlocked bool
+
+	// StepInterval, if non-zero, re-arms the timer after it fires so
+	// it fires again every StepInterval of simulated time, turning a
+	// one-shot SimTimer into a ticker in all but name. Re-arming sends
+	// are always non-blocking and counted by Missed when dropped, the
+	// same as SkipIfBlocked, since a single Tick can cross more than
+	// one StepInterval boundary before the receiver gets a chance to
+	// drain the channel.
+	StepInterval time.Duration
+}
+
+// firstTimerOptions returns opts[0] if present, or the zero value
+// TimerOptions otherwise. NewTimer and NewTicker take opts as a
+// variadic parameter so existing callers that don't need any of this
+// don't have to pass anything.
+func firstTimerOptions(opts []TimerOptions) TimerOptions {
+	if len(opts) == 0 {
+		return TimerOptions{}
+	}
+
+	return opts[0]
+}
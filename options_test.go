@@ -0,0 +1,125 @@
+package simtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerSkipIfBlockedDropsUnreadEvents(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	period := 100 * time.Millisecond
+	timer, ch := clock.NewTimer(period, TimerOptions{
+		SkipIfBlocked: true,
+		StepInterval:  period,
+	})
+
+	// Cross several period boundaries in one Tick without reading ch:
+	// everything but the first should be dropped.
+	clock.Tick(5 * period)
+
+	select {
+	case <-ch:
+	default:
+		t.Fatalf("timer did not fire")
+	}
+
+	if timer.Missed() == 0 {
+		t.Errorf("timer.Missed() is 0, want at least one dropped event")
+	}
+}
+
+func TestTimerStepIntervalRearmsAfterFiring(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	period := 1 * time.Second
+	_, ch := clock.NewTimer(period, TimerOptions{StepInterval: period})
+
+	fires := 0
+	tickSize := 100 * time.Millisecond
+	for range int(period/tickSize)*3 + 1 {
+		select {
+		case <-ch:
+			fires++
+		default:
+		}
+
+		clock.Tick(tickSize)
+	}
+
+	if fires < 3 {
+		t.Errorf("timer fired %d times, want at least 3", fires)
+	}
+}
+
+func TestTimerStepIntervalRearmDoesNotBlockAcrossMultipleBoundaries(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	period := 1 * time.Second
+	timer, ch := clock.NewTimer(period, TimerOptions{StepInterval: period})
+
+	// Cross several StepInterval boundaries in one Tick without reading
+	// ch in between. A re-arming fire must never block on the full
+	// buffer-1 channel, or this call hangs forever.
+	clock.Tick(5 * period)
+
+	select {
+	case <-ch:
+	default:
+		t.Fatalf("timer did not fire")
+	}
+
+	if timer.Missed() == 0 {
+		t.Errorf("timer.Missed() is 0, want at least one dropped re-arm")
+	}
+}
+
+func TestTimerWithoutOptionsStopsAfterFiringOnce(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	dur := 1 * time.Second
+	timer, ch := clock.NewTimer(dur)
+
+	clock.Tick(10 * dur)
+
+	fires := 0
+	for {
+		select {
+		case <-ch:
+			fires++
+		default:
+			goto done
+		}
+	}
+done:
+	if fires != 1 {
+		t.Errorf("timer fired %d times, want exactly 1", fires)
+	}
+
+	if timer.Missed() != 0 {
+		t.Errorf("timer.Missed() is %d, want 0 without SkipIfBlocked", timer.Missed())
+	}
+}
+
+func TestTickerMissedCountsDroppedTicks(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	period := 100 * time.Millisecond
+	ticker, _ := clock.NewTicker(period)
+
+	clock.Tick(5 * period)
+
+	if ticker.Missed() == 0 {
+		t.Errorf("ticker.Missed() is 0, want at least one dropped tick")
+	}
+}
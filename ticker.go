@@ -0,0 +1,137 @@
+package simtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vlence/gossert"
+)
+
+// A Ticker represents a repeating event. The interface is deliberately
+// kept similar to that of *time.Ticker.
+type Ticker interface {
+	Reset(d time.Duration)
+
+	Stop()
+
+	// Missed returns the number of ticks this ticker has dropped
+	// because nothing was ready to receive when it fired.
+	Missed() int
+}
+
+// SimTicker represents a simulated ticker. Like SimTimer it only
+// advances when the SimClock backing it is Tick'ed, except it keeps
+// firing every period instead of just once.
+type SimTicker struct {
+	ch       chan time.Time
+	mu       *sync.Mutex
+	stopped  bool
+	deadline SimMonotonicTime
+	period   time.Duration
+	seq      uint64
+	missed   int
+	events   *timerEvents
+}
+
+// newSimTicker returns a new SimTicker whose first tick is at deadline
+// and which ticks every period thereafter. seq is its insertion
+// sequence number, used to order it against other timers sharing its
+// deadline.
+func newSimTicker(deadline SimMonotonicTime, period time.Duration, seq uint64, events *timerEvents) *SimTicker {
+	gossert.Ok(period > 0, "simticker: period must be positive")
+	gossert.Ok(events != nil, "simticker: timer events is nil")
+
+	// Buffer size 1 so Tick'ing the clock doesn't block waiting for a
+	// receiver. If the receiver hasn't read the last tick by the time
+	// the next one is due we drop it, same as *time.Ticker.
+	ch := make(chan time.Time, 1)
+
+	ticker := new(SimTicker)
+	ticker.ch = ch
+	ticker.mu = new(sync.Mutex)
+	ticker.stopped = false
+	ticker.deadline = deadline
+	ticker.period = period
+	ticker.seq = seq
+	ticker.events = events
+
+	return ticker
+}
+
+// Reset changes the ticker's period to d. The next tick fires d after
+// Reset is called. If the ticker has been stopped, Reset does nothing.
+func (ticker *SimTicker) Reset(d time.Duration) {
+	ticker.mu.Lock()
+	defer ticker.mu.Unlock()
+
+	gossert.Ok(d > 0, "simticker: period must be positive")
+
+	if ticker.stopped {
+		return
+	}
+
+	ticker.period = d
+	ticker.deadline = ticker.events.clock.NowMonotonic().Add(d)
+}
+
+// Stop turns off the ticker. After Stop, no more ticks will be sent.
+// Stop does not close the channel, to prevent a read from the channel
+// succeeding incorrectly.
+func (ticker *SimTicker) Stop() {
+	ticker.mu.Lock()
+	defer ticker.mu.Unlock()
+
+	if ticker.stopped {
+		return
+	}
+
+	ticker.events.remove(ticker)
+	ticker.stopped = true
+}
+
+// Missed returns the number of ticks this ticker has dropped because
+// nothing was ready to receive when it fired.
+func (ticker *SimTicker) Missed() int {
+	ticker.mu.Lock()
+	defer ticker.mu.Unlock()
+
+	return ticker.missed
+}
+
+// nextDeadline returns the time at which the ticker will next fire.
+func (ticker *SimTicker) nextDeadline() SimMonotonicTime {
+	ticker.mu.Lock()
+	defer ticker.mu.Unlock()
+
+	return ticker.deadline
+}
+
+// insertionSeq returns the ticker's insertion sequence number.
+func (ticker *SimTicker) insertionSeq() uint64 {
+	return ticker.seq
+}
+
+// fire sends a tick for every period boundary that now has passed,
+// advancing the deadline past now in the process. Like *time.Ticker,
+// SimTicker adjusts the interval or drops ticks to make up for a slow
+// receiver instead of blocking or queueing them up.
+func (ticker *SimTicker) fire(now SimMonotonicTime) bool {
+	ticker.mu.Lock()
+	defer ticker.mu.Unlock()
+
+	if ticker.stopped {
+		return false
+	}
+
+	for !now.Before(ticker.deadline) {
+		select {
+		case ticker.ch <- ticker.events.epoch.Add(time.Duration(now)):
+		default:
+			ticker.missed++
+		}
+
+		ticker.deadline = ticker.deadline.Add(ticker.period)
+	}
+
+	return false
+}
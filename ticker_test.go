@@ -0,0 +1,97 @@
+package simtest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTickerHasExpectedDeadline(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	d := 1 * time.Second
+	expectedDeadline := epoch.Add(d)
+
+	tt, _ := clock.NewTicker(d)
+	ticker, _ := tt.(*SimTicker)
+
+	deadline := clock.events.epoch.Add(time.Duration(ticker.deadline))
+
+	if !expectedDeadline.Equal(deadline) {
+		t.Errorf("ticker's deadline %s does not match expected deadline %s", deadline, expectedDeadline)
+	}
+}
+
+func TestTickerFiresRepeatedly(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	period := 1 * time.Second
+	_, ch := clock.NewTicker(period)
+
+	tickSize := 100 * time.Millisecond
+	ticksPerPeriod := period / tickSize
+
+	wantFires := 3
+	fires := 0
+	for range int(ticksPerPeriod)*wantFires + 1 {
+		select {
+		case <-ch:
+			fires++
+		default:
+		}
+
+		clock.Tick(tickSize)
+	}
+
+	if fires < wantFires {
+		t.Errorf("ticker fired %d times, expected at least %d", fires, wantFires)
+	}
+}
+
+func TestTickerFiresOnceWhenMultipleBoundariesCrossedInOneTick(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	period := 100 * time.Millisecond
+	_, ch := clock.NewTicker(period)
+
+	// One big Tick crosses many period boundaries at once. The slow
+	// receiver should only see one queued tick, not one per boundary.
+	clock.Tick(10 * period)
+
+	fires := 0
+	for {
+		select {
+		case <-ch:
+			fires++
+		default:
+			goto done
+		}
+	}
+done:
+	if fires != 1 {
+		t.Errorf("ticker queued %d ticks for a slow receiver, expected 1", fires)
+	}
+}
+
+func TestTickerStoppedDoesNotFire(t *testing.T) {
+	epoch := time.Now()
+	clock := NewSimClock(epoch)
+	defer clock.Stop()
+
+	period := 1 * time.Second
+	ticker, ch := clock.NewTicker(period)
+	ticker.Stop()
+
+	clock.Tick(10 * period)
+
+	select {
+	case <-ch:
+		t.Errorf("stopped ticker fired")
+	default:
+	}
+}
@@ -13,6 +13,12 @@ type Timer interface {
         Reset(d time.Duration) bool
 
         Stop() bool
+
+        // Missed returns the number of events this timer has dropped
+        // because it was created with TimerOptions.SkipIfBlocked or a
+        // non-zero TimerOptions.StepInterval and nothing was ready to
+        // receive when it fired.
+        Missed() int
 }
 
 // SimTimer represents a simulater timer. Timers are used to represent
@@ -23,17 +29,17 @@ type SimTimer struct {
         ch       chan time.Time
         mu       *sync.Mutex
         stopped  bool
-        deadline time.Time
+        deadline SimMonotonicTime
+        seq      uint64
+        opts     TimerOptions
+        missed   int
         events   *timerEvents
 }
 
-// newSimTimer returns a new SimTimer.
-func newSimTimer(deadline time.Time, events *timerEvents) *SimTimer {
+// newSimTimer returns a new SimTimer. seq is its insertion sequence
+// number, used to order it against other timers sharing its deadline.
+func newSimTimer(deadline SimMonotonicTime, seq uint64, opts TimerOptions, events *timerEvents) *SimTimer {
         gossert.Ok(events != nil, "simtimer: timer events is nil")
-        gossert.Ok(events.add != nil, "simtimer: add timer event is nil")
-        gossert.Ok(events.remove != nil, "simtimer: remove timer event is nil")
-        gossert.Ok(events.stop != nil, "simtimer: stop timer event is nil")
-        gossert.Ok(events.tick != nil, "simtimer: tick timer event is nil")
 
         // Using buffer size 1 so that we aren't blocked if nobody is
         // waiting for a message from the channel yet. Size 1 is good
@@ -47,6 +53,8 @@ func newSimTimer(deadline time.Time, events *timerEvents) *SimTimer {
         timer.mu = new(sync.Mutex)
         timer.stopped = false
         timer.deadline = deadline
+        timer.seq = seq
+        timer.opts = opts
         timer.events = events
 
         return timer
@@ -79,16 +87,47 @@ func (timer *SimTimer) Stop() bool {
                 return false
         }
 
-        timer.events.remove <- timer
+        timer.events.remove(timer)
         timer.stopped = true
 
         return true
 }
 
-// fire fires the timer if its deadline has been reached.
-// The timer's deadline is reached if the current time now
-// is after or equal to the deadline.
-func (timer *SimTimer) fire(now time.Time) bool {
+// Missed returns the number of events this timer has dropped because it
+// was created with TimerOptions.SkipIfBlocked or a non-zero
+// TimerOptions.StepInterval and nothing was ready to receive when it
+// fired.
+func (timer *SimTimer) Missed() int {
+        timer.mu.Lock()
+        defer timer.mu.Unlock()
+
+        return timer.missed
+}
+
+// nextDeadline returns the time at which the timer will next fire.
+func (timer *SimTimer) nextDeadline() SimMonotonicTime {
+	timer.mu.Lock()
+	defer timer.mu.Unlock()
+
+	return timer.deadline
+}
+
+// insertionSeq returns the timer's insertion sequence number.
+func (timer *SimTimer) insertionSeq() uint64 {
+	return timer.seq
+}
+
+// fire fires the timer if its deadline has been reached. The timer's
+// deadline is reached if the current time now is after or equal to the
+// deadline. If the timer was created with a StepInterval, it re-arms
+// itself to fire again StepInterval later instead of stopping, turning
+// it into a ticker in all but name. Because a single Tick can cross
+// several StepInterval boundaries before fire is next called, a
+// re-arming fire always sends non-blocking, the same as SimTicker,
+// regardless of SkipIfBlocked: a blocking send here could never be
+// drained between boundaries and would hang the caller advancing the
+// clock forever.
+func (timer *SimTimer) fire(now SimMonotonicTime) bool {
         timer.mu.Lock()
         defer timer.mu.Unlock()
 
@@ -102,8 +141,24 @@ func (timer *SimTimer) fire(now time.Time) bool {
                 return false
         }
 
+        value := timer.events.epoch.Add(time.Duration(now))
+
+        if timer.opts.SkipIfBlocked || timer.opts.StepInterval > 0 {
+                select {
+                case timer.ch <- value:
+                default:
+                        timer.missed++
+                }
+        } else {
+                timer.ch <- value
+        }
+
+        if timer.opts.StepInterval > 0 {
+                timer.deadline = timer.deadline.Add(timer.opts.StepInterval)
+                return false
+        }
+
         timer.stopped = true
-        timer.ch <- now
 
         return true
 }
@@ -17,8 +17,10 @@ func TestTimerHasExpectedDeadline(t *testing.T) {
         tt, _ := clock.NewTimer(d)
         timer, _ := tt.(*SimTimer)
 
-        if !expectedDeadline.Equal(timer.deadline) {
-                t.Errorf("timer's deadline %s does not match expected deadline %s", timer.deadline, expectedDeadline)
+        deadline := clock.events.epoch.Add(time.Duration(timer.deadline))
+
+        if !expectedDeadline.Equal(deadline) {
+                t.Errorf("timer's deadline %s does not match expected deadline %s", deadline, expectedDeadline)
         }
 }
 
@@ -38,8 +40,9 @@ func TestTimerIsFiredAtDeadline(t *testing.T) {
         for range iters {
                 select {
                 case now := <-ch:
-                        if !now.Equal(timer.deadline) {
-                                t.Errorf("timer fired at %s but should have been fired at %s", now, timer.deadline)
+                        deadline := clock.events.epoch.Add(time.Duration(timer.deadline))
+                        if !now.Equal(deadline) {
+                                t.Errorf("timer fired at %s but should have been fired at %s", now, deadline)
                         }
                         return
                 default:
@@ -65,8 +68,9 @@ func TestTimerIsFiredAfterDeadline(t *testing.T) {
         for range iters {
                 select {
                 case now := <-ch:
-                        if !now.After(timer.deadline) {
-                                t.Errorf("timer fired at %s but should have been fired after %s", now, timer.deadline)
+                        deadline := clock.events.epoch.Add(time.Duration(timer.deadline))
+                        if !now.After(deadline) {
+                                t.Errorf("timer fired at %s but should have been fired after %s", now, deadline)
                         }
                         return
                 default: